@@ -0,0 +1,178 @@
+package reZ
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// CachedResponse holds a previously captured stream so it can be replayed
+// without contacting bigmodel.cn again. Events are stored in the exact
+// order they were received so replay preserves the original streaming
+// shape (Think chunks, Text chunks, ToolCall events, and the raw payload).
+type CachedResponse struct {
+	Events []StreamEvent
+}
+
+// Cache is the interface a response cache must implement to be plugged
+// into a Client via NewClientWithCache. Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	// Get returns the cached response for key, if present and not expired.
+	Get(key string) (*CachedResponse, bool)
+	// Set stores r under key. The entry expires after ttl; a zero ttl
+	// means the entry never expires.
+	Set(key string, r *CachedResponse, ttl time.Duration)
+}
+
+// lruCacheEntry is the value stored in the LRU's linked list.
+type lruCacheEntry struct {
+	key       string
+	resp      *CachedResponse
+	expiresAt time.Time // zero means no expiry
+}
+
+// lruCache is the default in-memory Cache implementation. It evicts the
+// least-recently-used entry once maxEntries is exceeded and treats any
+// entry past its expiresAt as a miss.
+type lruCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	items      map[string]*list.Element
+}
+
+// NewLRUCache creates an in-memory Cache that holds at most maxEntries
+// entries, evicting the least-recently-used one when full. A maxEntries
+// of 0 or less means unbounded.
+//
+// Example:
+//
+//	client := reZ.NewClientWithCache(reZ.NewLRUCache(500), 10*time.Minute)
+func NewLRUCache(maxEntries int) Cache {
+	return &lruCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func (c *lruCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*lruCacheEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.items, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.resp, true
+}
+
+func (c *lruCache) Set(key string, r *CachedResponse, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value = &lruCacheEntry{key: key, resp: r, expiresAt: expiresAt}
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&lruCacheEntry{key: key, resp: r, expiresAt: expiresAt})
+	c.items[key] = elem
+
+	if c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruCacheEntry).key)
+		}
+	}
+}
+
+// WithCacheTTL overrides the cache's default TTL for this single request.
+// Has no effect if the client was not created with NewClientWithCache.
+//
+// Example:
+//
+//	client.Chat(ctx, "Hello", reZ.WithCacheTTL(time.Minute))
+func WithCacheTTL(d time.Duration) Option {
+	return func(r *Request) {
+		r.cacheTTL = &d
+	}
+}
+
+// WithNoCache disables the response cache for this single request, even
+// if the client has one configured. Use this for requests that must
+// always hit bigmodel.cn, such as ones relying on non-deterministic
+// tools or fresh web search results.
+//
+// Example:
+//
+//	client.Chat(ctx, "What time is it?", reZ.WithNoCache())
+func WithNoCache() Option {
+	return func(r *Request) {
+		r.noCache = true
+	}
+}
+
+// cacheKey returns a stable hash of the request's canonical JSON encoding.
+// Unexported fields (such as cacheTTL and noCache) are never marshaled,
+// so two requests that only differ in cache options collide as intended.
+func cacheKey(req *Request) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// replay re-emits a cached response's events over a fresh channel,
+// waiting delay between each one so the original streaming shape is
+// preserved for consumers that pace themselves on event arrival. It
+// honors ctx like every other streaming path in the client, stopping
+// early instead of leaking its goroutine if the caller cancels.
+func (c *Client) replay(ctx context.Context, cached *CachedResponse, delay time.Duration) <-chan StreamEvent {
+	eventCh := make(chan StreamEvent, defaultChannelBuffer)
+
+	go func() {
+		defer close(eventCh)
+		for i, event := range cached.Events {
+			if i > 0 && delay > 0 {
+				t := time.NewTimer(delay)
+				select {
+				case <-ctx.Done():
+					t.Stop()
+					return
+				case <-t.C:
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case eventCh <- event:
+			}
+		}
+	}()
+
+	return eventCh
+}
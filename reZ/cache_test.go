@@ -0,0 +1,123 @@
+package reZ
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRUCacheGetSetRoundTrip(t *testing.T) {
+	c := NewLRUCache(10)
+	resp := &CachedResponse{Events: []StreamEvent{{Text: "hi"}}}
+
+	c.Set("key", resp, 0)
+
+	got, ok := c.Get("key")
+	if !ok {
+		t.Fatal("Get() ok = false, want true")
+	}
+	if got != resp {
+		t.Errorf("Get() = %v, want %v", got, resp)
+	}
+}
+
+func TestLRUCacheMiss(t *testing.T) {
+	c := NewLRUCache(10)
+	if _, ok := c.Get("missing"); ok {
+		t.Error("Get() ok = true for absent key, want false")
+	}
+}
+
+func TestLRUCacheTTLExpiry(t *testing.T) {
+	c := NewLRUCache(10)
+	c.Set("key", &CachedResponse{}, 10*time.Millisecond)
+
+	time.Sleep(50 * time.Millisecond)
+
+	if _, ok := c.Get("key"); ok {
+		t.Error("Get() ok = true after TTL elapsed, want false")
+	}
+}
+
+func TestLRUCacheZeroTTLNeverExpires(t *testing.T) {
+	c := NewLRUCache(10)
+	c.Set("key", &CachedResponse{}, 0)
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := c.Get("key"); !ok {
+		t.Error("Get() ok = false for zero-TTL entry, want true")
+	}
+}
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRUCache(2)
+	c.Set("a", &CachedResponse{}, 0)
+	c.Set("b", &CachedResponse{}, 0)
+
+	// Touch "a" so "b" becomes the least-recently-used entry.
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("Get(a) ok = false, want true")
+	}
+
+	c.Set("c", &CachedResponse{}, 0)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get(b) ok = true, want false: b should have been evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Error("Get(a) ok = false, want true: a was recently used and should survive")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Error("Get(c) ok = false, want true")
+	}
+}
+
+func TestReplayEmitsAllEvents(t *testing.T) {
+	c := &Client{}
+	cached := &CachedResponse{Events: []StreamEvent{{Text: "a"}, {Text: "b"}, {Text: "c"}}}
+
+	ch := c.replay(context.Background(), cached, 0)
+
+	var got []StreamEvent
+	for ev := range ch {
+		got = append(got, ev)
+	}
+	if len(got) != len(cached.Events) {
+		t.Fatalf("got %d events, want %d", len(got), len(cached.Events))
+	}
+	for i, ev := range got {
+		if ev.Text != cached.Events[i].Text {
+			t.Errorf("event %d = %q, want %q", i, ev.Text, cached.Events[i].Text)
+		}
+	}
+}
+
+// TestReplayStopsOnContextCancel guards against the goroutine leak fixed
+// when replay ignored ctx: with a cancellation mid-delay, the goroutine
+// must return instead of blocking forever trying to send into an
+// abandoned channel.
+func TestReplayStopsOnContextCancel(t *testing.T) {
+	c := &Client{}
+	events := make([]StreamEvent, 10)
+	for i := range events {
+		events[i] = StreamEvent{Text: "event"}
+	}
+	cached := &CachedResponse{Events: events}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := c.replay(ctx, cached, 50*time.Millisecond)
+
+	// Drain exactly one event, then cancel; the rest must never arrive.
+	<-ch
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("received a second event after cancellation, want the channel to drain and close promptly")
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("replay goroutine did not stop within 1s of context cancellation")
+	}
+}
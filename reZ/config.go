@@ -0,0 +1,162 @@
+package reZ
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Config holds credentials and connection settings for a Client. Pass it
+// to NewClientWithConfig. Zero-value fields fall back to the same trial
+// defaults (and ZHIPU_* environment variables) that NewClient uses.
+type Config struct {
+	AuthToken     string
+	Organization  string
+	Project       string
+	Cookie        []*http.Cookie
+	BaseURL       string
+	HTTPClient    *http.Client
+	UserAgent     string
+	TokenProvider TokenProvider
+}
+
+// applyTo overrides c's fields with any non-zero Config fields.
+func (cfg Config) applyTo(c *Client) {
+	if cfg.AuthToken != "" {
+		c.authToken = cfg.AuthToken
+	}
+	if cfg.Organization != "" {
+		c.organization = cfg.Organization
+	}
+	if cfg.Project != "" {
+		c.project = cfg.Project
+	}
+	if cfg.Cookie != nil {
+		c.cookies = cfg.Cookie
+	}
+	if cfg.BaseURL != "" {
+		c.baseURL = cfg.BaseURL
+	}
+	if cfg.HTTPClient != nil {
+		c.httpClient = cfg.HTTPClient
+	}
+	if cfg.UserAgent != "" {
+		c.userAgent = cfg.UserAgent
+	}
+	if cfg.TokenProvider != nil {
+		c.tokenProvider = cfg.TokenProvider
+	}
+}
+
+// TokenProvider supplies an Authorization token per request, so it can be
+// rotated out-of-band (session refresh, short-lived tokens, multi-tenant
+// proxies issuing one token per caller). When set on a Client, it takes
+// precedence over any static auth token.
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// ClientOption configures a Client at construction time.
+type ClientOption func(*Client)
+
+// WithAuthToken sets the Authorization header value sent with every
+// request. Overridden per request if the client has a TokenProvider.
+func WithAuthToken(token string) ClientOption {
+	return func(c *Client) {
+		c.authToken = token
+	}
+}
+
+// WithOrganization sets the Bigmodel-Organization header value.
+func WithOrganization(org string) ClientOption {
+	return func(c *Client) {
+		c.organization = org
+	}
+}
+
+// WithProject sets the Bigmodel-Project header value.
+func WithProject(project string) ClientOption {
+	return func(c *Client) {
+		c.project = project
+	}
+}
+
+// WithCookie sets the cookies sent with every request. Use
+// ParseCookieHeader to build the slice from a raw "Cookie:" header value,
+// for example one captured from a browser session.
+func WithCookie(cookies []*http.Cookie) ClientOption {
+	return func(c *Client) {
+		c.cookies = cookies
+	}
+}
+
+// WithBaseURL overrides the endpoint requests are sent to. Useful for
+// pointing the client at a proxy or a mock server in tests.
+func WithBaseURL(url string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = url
+	}
+}
+
+// WithHTTPClient overrides the *http.Client used to send requests, for
+// example to set a custom Transport, timeout, or proxy.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithUserAgent overrides the User-Agent header sent with every request.
+func WithUserAgent(ua string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
+
+// WithTokenProvider installs a TokenProvider that supplies the
+// Authorization token per request, taking precedence over any static
+// auth token from WithAuthToken, Config.AuthToken, or ZHIPU_AUTH_TOKEN.
+func WithTokenProvider(tp TokenProvider) ClientOption {
+	return func(c *Client) {
+		c.tokenProvider = tp
+	}
+}
+
+// ParseCookieHeader parses a raw "Cookie:" header value (such as one
+// copied from a browser's network inspector) into the []*http.Cookie
+// slice expected by WithCookie and Config.Cookie.
+//
+// Example:
+//
+//	cookies := reZ.ParseCookieHeader("session=abc123; theme=dark")
+//	client := reZ.NewClient(reZ.WithCookie(cookies))
+func ParseCookieHeader(raw string) []*http.Cookie {
+	return parseCookieHeader(raw)
+}
+
+func parseCookieHeader(raw string) []*http.Cookie {
+	if raw == "" {
+		return nil
+	}
+	header := http.Header{}
+	header.Add("Cookie", raw)
+	req := &http.Request{Header: header}
+	return req.Cookies()
+}
+
+func cookieHeaderValue(cookies []*http.Cookie) string {
+	parts := make([]string, 0, len(cookies))
+	for _, c := range cookies {
+		parts = append(parts, c.Name+"="+c.Value)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// envOr returns the environment variable key's value, or fallback if unset.
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,130 @@
+package reZ
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrStreamIdle indicates a streaming read was aborted because no SSE
+// event arrived within the configured idle timeout. See
+// WithStreamIdleTimeout.
+var ErrStreamIdle = errors.New("reZ: stream idle timeout")
+
+// ErrFirstByteTimeout indicates no event arrived within the configured
+// first-byte timeout after the request was sent. See
+// WithFirstByteTimeout.
+var ErrFirstByteTimeout = errors.New("reZ: first byte timeout")
+
+// WithStreamIdleTimeout arms a timer that is reset every time an SSE
+// event is received. If d elapses without one, the underlying response
+// body is closed and the event channel emits StreamEvent{Error:
+// ErrStreamIdle} before closing. A zero duration (the default) disables
+// the idle timeout.
+//
+// Example:
+//
+//	client := reZ.NewClient(reZ.WithStreamIdleTimeout(30 * time.Second))
+func WithStreamIdleTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.streamIdleTimeout = d
+	}
+}
+
+// WithFirstByteTimeout bounds how long to wait for the first SSE event
+// after a request is sent, distinct from the overall context deadline
+// (which also covers marshaling, connecting, and every later event). A
+// zero duration (the default) disables the first-byte timeout.
+//
+// Example:
+//
+//	client := reZ.NewClient(reZ.WithFirstByteTimeout(5 * time.Second))
+func WithFirstByteTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.firstByteTimeout = d
+	}
+}
+
+// idleTimer aborts body's read side if it isn't reset within d. It
+// borrows the deadline-timer pattern netstack's gonet adapter uses for
+// net.Conn.SetReadDeadline, but instead of swapping in a fresh *time.Timer
+// on every Reset (which only protects against two goroutines touching the
+// same Timer, not against a callback that has already started running
+// when a Reset races it), arm/fire/Stop all hold the same mutex and fire
+// checks that it's still the current generation before acting. That
+// closes the race the naive swap leaves open: a fresh event arriving the
+// instant the old timer fires can no longer cause a stale callback to
+// declare the stream idle and close a live connection.
+type idleTimer struct {
+	d    time.Duration
+	body io.Closer
+
+	mu      sync.Mutex
+	timer   *time.Timer
+	gen     uint64 // bumped on every arm; fire ignores callbacks from a stale generation
+	live    bool   // false once stopped or already fired
+	expired bool
+}
+
+// newIdleTimer creates an idleTimer already armed for d; body is closed
+// if it isn't reset or stopped before d elapses.
+func newIdleTimer(d time.Duration, body io.Closer) *idleTimer {
+	it := &idleTimer{d: d, body: body, live: true}
+	it.arm()
+	return it
+}
+
+func (it *idleTimer) arm() {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	if !it.live {
+		return
+	}
+	if it.timer != nil {
+		it.timer.Stop()
+	}
+	it.gen++
+	gen := it.gen
+	it.timer = time.AfterFunc(it.d, func() { it.fire(gen) })
+}
+
+// fire runs on the timer's own goroutine. gen identifies which arm
+// invoked it, so a fire that lost the race against a concurrent Reset
+// (which already bumped it.gen) recognizes it's stale and does nothing
+// instead of closing a connection that just saw a fresh event. gen is
+// passed by value rather than captured by reference, so there's no
+// window where the callback could observe it before arm finishes
+// assigning it.
+func (it *idleTimer) fire(gen uint64) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	if !it.live || it.gen != gen {
+		return
+	}
+	it.expired = true
+	it.live = false
+	it.body.Close()
+}
+
+// Reset pushes the deadline out by d from now.
+func (it *idleTimer) Reset() {
+	it.arm()
+}
+
+// Stop disarms the timer. Safe to call after it has already fired.
+func (it *idleTimer) Stop() {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	it.live = false
+	if it.timer != nil {
+		it.timer.Stop()
+	}
+}
+
+// Expired reports whether the timer fired and closed body.
+func (it *idleTimer) Expired() bool {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+	return it.expired
+}
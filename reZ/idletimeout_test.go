@@ -0,0 +1,99 @@
+package reZ
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingCloser records how many times Close was called, so tests can
+// assert the idleTimer closed the body exactly once.
+type countingCloser struct {
+	closes int32
+}
+
+func (c *countingCloser) Close() error {
+	atomic.AddInt32(&c.closes, 1)
+	return nil
+}
+
+func TestIdleTimerFiresWhenNotReset(t *testing.T) {
+	body := &countingCloser{}
+	it := newIdleTimer(10*time.Millisecond, body)
+
+	time.Sleep(100 * time.Millisecond)
+
+	if !it.Expired() {
+		t.Error("Expired() = false, want true after idle timeout elapsed")
+	}
+	if got := atomic.LoadInt32(&body.closes); got != 1 {
+		t.Errorf("body closed %d times, want 1", got)
+	}
+}
+
+func TestIdleTimerResetPreventsExpiry(t *testing.T) {
+	body := &countingCloser{}
+	it := newIdleTimer(50*time.Millisecond, body)
+
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		it.Reset()
+	}
+
+	if it.Expired() {
+		t.Error("Expired() = true, want false: Reset was called faster than the timeout")
+	}
+	if got := atomic.LoadInt32(&body.closes); got != 0 {
+		t.Errorf("body closed %d times, want 0", got)
+	}
+}
+
+func TestIdleTimerStopPreventsLateFire(t *testing.T) {
+	body := &countingCloser{}
+	it := newIdleTimer(10*time.Millisecond, body)
+	it.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+
+	if it.Expired() {
+		t.Error("Expired() = true, want false after Stop")
+	}
+	if got := atomic.LoadInt32(&body.closes); got != 0 {
+		t.Errorf("body closed %d times, want 0", got)
+	}
+}
+
+// TestIdleTimerConcurrentResetRace hammers Reset from many goroutines right
+// up against the timer's own deadline, the scenario that broke the naive
+// swap-a-fresh-timer approach: a stale in-flight fire callback could still
+// close the body after a concurrent Reset had already superseded it. Run
+// with -race to catch any data race in arm/fire's shared state.
+func TestIdleTimerConcurrentResetRace(t *testing.T) {
+	body := &countingCloser{}
+	it := newIdleTimer(5*time.Millisecond, body)
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					it.Reset()
+				}
+			}
+		}()
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	close(stop)
+	wg.Wait()
+
+	it.Stop()
+}
@@ -0,0 +1,136 @@
+package reZ
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how a Client retries a request after a transient
+// failure: a network error or an HTTP 429/5xx response. The zero value
+// disables retries entirely (a single attempt), matching the client's
+// behavior before RetryPolicy existed.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// 0 or 1 disables retry.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the second attempt.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the computed delay between attempts. 0 means
+	// unbounded.
+	MaxBackoff time.Duration
+
+	// Multiplier is applied to the backoff after each attempt. 2 doubles
+	// the delay each time.
+	Multiplier float64
+
+	// Jitter randomizes the computed backoff by +/- this fraction (e.g.
+	// 0.2 for +/-20%), to avoid retry storms when many clients back off
+	// in lockstep.
+	Jitter float64
+
+	// RetryableFunc decides whether a given response/error pair should
+	// be retried. Defaults to retrying network errors and HTTP 429/5xx
+	// responses when nil.
+	RetryableFunc func(resp *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with sensible defaults: up to
+// 3 attempts, starting at 500ms and doubling up to 10s, with 20% jitter.
+//
+// Example:
+//
+//	client := reZ.NewClient(reZ.WithRetry(reZ.DefaultRetryPolicy()))
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// WithRetry enables automatic retry with exponential backoff for
+// transient failures, and lets ChatWithHistory transparently reconnect
+// mid-stream disconnects instead of surfacing them as terminal errors.
+// Without this option the client makes a single attempt.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+func (p RetryPolicy) retryable(resp *http.Response, err error) bool {
+	if p.RetryableFunc != nil {
+		return p.RetryableFunc(resp, err)
+	}
+	if err != nil {
+		return true
+	}
+	return resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500)
+}
+
+// backoff returns the delay before the attempt'th retry (0-indexed: 0 is
+// the delay before the second overall attempt).
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		delta := d * p.Jitter
+		d += (rand.Float64()*2 - 1) * delta
+		if d < 0 {
+			d = 0
+		}
+	}
+
+	return time.Duration(d)
+}
+
+// retryAfterDuration parses resp's Retry-After header, if present, as
+// either a number of seconds or an HTTP date.
+func retryAfterDuration(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
+
+// sleepContext waits for d, returning early with ctx's error if ctx is
+// canceled first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+package reZ
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoffGrowth(t *testing.T) {
+	p := RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     1 * time.Second,
+		Multiplier:     2,
+	}
+
+	want := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		1 * time.Second, // capped by MaxBackoff
+	}
+	for attempt, d := range want {
+		if got := p.backoff(attempt); got != d {
+			t.Errorf("backoff(%d) = %v, want %v", attempt, got, d)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffJitterBounds(t *testing.T) {
+	p := RetryPolicy{
+		InitialBackoff: 1 * time.Second,
+		Multiplier:     1,
+		Jitter:         0.2,
+	}
+
+	base := float64(p.InitialBackoff)
+	min := time.Duration(base * 0.8)
+	max := time.Duration(base * 1.2)
+	for i := 0; i < 100; i++ {
+		d := p.backoff(0)
+		if d < min || d > max {
+			t.Fatalf("backoff(0) = %v, want within [%v, %v]", d, min, max)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffNoNegative(t *testing.T) {
+	p := RetryPolicy{
+		InitialBackoff: 1 * time.Millisecond,
+		Multiplier:     1,
+		Jitter:         5, // deliberately huge to try to push the result negative
+	}
+	for i := 0; i < 100; i++ {
+		if d := p.backoff(0); d < 0 {
+			t.Fatalf("backoff(0) = %v, want >= 0", d)
+		}
+	}
+}
+
+func TestRetryAfterDurationSeconds(t *testing.T) {
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"5"}}}
+	d, ok := retryAfterDuration(resp)
+	if !ok {
+		t.Fatal("retryAfterDuration() ok = false, want true")
+	}
+	if d != 5*time.Second {
+		t.Errorf("retryAfterDuration() = %v, want 5s", d)
+	}
+}
+
+func TestRetryAfterDurationHTTPDate(t *testing.T) {
+	future := time.Now().Add(10 * time.Second).UTC()
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{future.Format(http.TimeFormat)}}}
+	d, ok := retryAfterDuration(resp)
+	if !ok {
+		t.Fatal("retryAfterDuration() ok = false, want true")
+	}
+	if d <= 0 || d > 11*time.Second {
+		t.Errorf("retryAfterDuration() = %v, want roughly 10s", d)
+	}
+}
+
+func TestRetryAfterDurationMissingOrInvalid(t *testing.T) {
+	if _, ok := retryAfterDuration(nil); ok {
+		t.Error("retryAfterDuration(nil) ok = true, want false")
+	}
+	if _, ok := retryAfterDuration(&http.Response{Header: http.Header{}}); ok {
+		t.Error("retryAfterDuration() with no header: ok = true, want false")
+	}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"not-a-value"}}}
+	if _, ok := retryAfterDuration(resp); ok {
+		t.Error("retryAfterDuration() with garbage header: ok = true, want false")
+	}
+}
+
+func TestSleepContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sleepContext(ctx, 1*time.Second); err == nil {
+		t.Error("sleepContext() with canceled ctx returned nil error, want ctx.Err()")
+	}
+}
+
+func TestSleepContextCompletes(t *testing.T) {
+	if err := sleepContext(context.Background(), 1*time.Millisecond); err != nil {
+		t.Errorf("sleepContext() = %v, want nil", err)
+	}
+}
@@ -0,0 +1,432 @@
+// Package server exposes an HTTP server that implements OpenAI's
+// /v1/chat/completions and /v1/models endpoints on top of a reZ.Client,
+// so the client can be used as a drop-in backend for any OpenAI SDK.
+//
+// Example usage:
+//
+//	client := reZ.NewClient()
+//	srv := server.NewServer(client,
+//	    server.WithModelAlias("gpt-4o", "glm-4.6"),
+//	    server.WithAPIKeyAuth("sk-local-key"))
+//	log.Fatal(http.ListenAndServe(":8080", srv.Handler()))
+package server
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zarazaex69/openscope/reZ"
+)
+
+// Server wraps a reZ.Client behind an OpenAI-compatible HTTP API.
+//
+// Each request's full `messages` array is translated into a prompt sent
+// via reZ.WithPrompt, a stateless call that never reads or writes the
+// underlying Client's shared conversation history. This keeps concurrent
+// requests against the same Server fully isolated from one another.
+type Server struct {
+	client *reZ.Client
+
+	apiKey       string
+	modelAliases map[string]string // OpenAI-facing name -> underlying reZ model name
+	logger       func(r *http.Request, status int, dur time.Duration)
+}
+
+// ServerOption configures a Server.
+type ServerOption func(*Server)
+
+// NewServer creates a Server that serves OpenAI-compatible endpoints on
+// top of client.
+//
+// Example:
+//
+//	srv := server.NewServer(reZ.NewClient())
+func NewServer(client *reZ.Client, opts ...ServerOption) *Server {
+	s := &Server{
+		client:       client,
+		modelAliases: make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// WithAPIKeyAuth requires requests to present key via an
+// "Authorization: Bearer <key>" header, matching OpenAI's auth scheme.
+// Without this option the server accepts any request.
+func WithAPIKeyAuth(key string) ServerOption {
+	return func(s *Server) {
+		s.apiKey = key
+	}
+}
+
+// WithModelAlias maps an OpenAI-facing model name (as sent by the
+// caller's SDK, e.g. "gpt-4o") onto the underlying reZ model name to
+// request (e.g. "glm-4.6"). Unaliased model names are passed through
+// unchanged via reZ.WithModel.
+//
+// Example:
+//
+//	server.WithModelAlias("gpt-4o", "glm-4.6")
+func WithModelAlias(alias, model string) ServerOption {
+	return func(s *Server) {
+		s.modelAliases[alias] = model
+	}
+}
+
+// WithRequestLogger installs a hook called once per request with the
+// resolved status code and handling duration.
+func WithRequestLogger(fn func(r *http.Request, status int, dur time.Duration)) ServerOption {
+	return func(s *Server) {
+		s.logger = fn
+	}
+}
+
+// Handler returns an http.Handler serving /v1/chat/completions and
+// /v1/models. Mount it directly or wrap it with additional middleware.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/chat/completions", s.withLogging(s.handleChatCompletions))
+	mux.HandleFunc("/v1/models", s.withLogging(s.handleModels))
+	return mux
+}
+
+// ListenAndServe is a convenience wrapper around http.ListenAndServe
+// using Handler().
+func (s *Server) ListenAndServe(addr string) error {
+	return http.ListenAndServe(addr, s.Handler())
+}
+
+func (s *Server) withLogging(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.authorize(r) {
+			writeError(w, http.StatusUnauthorized, "invalid_api_key", "Incorrect API key provided.")
+			return
+		}
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		h(sw, r)
+		if s.logger != nil {
+			s.logger(r, sw.status, time.Since(start))
+		}
+	}
+}
+
+func (s *Server) authorize(r *http.Request) bool {
+	if s.apiKey == "" {
+		return true
+	}
+	auth := r.Header.Get("Authorization")
+	return auth == "Bearer "+s.apiKey
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (s *Server) handleModels(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "only GET is supported")
+		return
+	}
+
+	now := time.Now().Unix()
+	data := []modelInfo{{ID: "glm-4.6", Object: "model", Created: now, OwnedBy: "zhipu"}}
+	for alias := range s.modelAliases {
+		data = append(data, modelInfo{ID: alias, Object: "model", Created: now, OwnedBy: "zhipu"})
+	}
+
+	writeJSON(w, http.StatusOK, modelsResponse{Object: "list", Data: data})
+}
+
+func (s *Server) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", "only POST is supported")
+		return
+	}
+
+	var req chatCompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", fmt.Sprintf("invalid JSON body: %v", err))
+		return
+	}
+	if len(req.Messages) == 0 {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", "messages must not be empty")
+		return
+	}
+
+	opts, err := s.translateRequest(&req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	ctx := r.Context()
+	events, err := s.client.Chat(ctx, "", opts...)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "upstream_error", err.Error())
+		return
+	}
+
+	id := "chatcmpl-" + randomID()
+	created := time.Now().Unix()
+
+	if req.Stream {
+		s.streamCompletion(w, events, id, created, req.Model)
+		return
+	}
+	s.aggregateCompletion(w, events, id, created, req.Model)
+}
+
+// translateRequest builds the reZ options for one request, including a
+// reZ.WithPrompt built from the full translated transcript. Each call is
+// stateless: it never reads or writes the Client's shared history, so
+// concurrent requests can't see each other's messages.
+func (s *Server) translateRequest(req *chatCompletionRequest) ([]reZ.Option, error) {
+	var systemPrompt strings.Builder
+	history := make([]reZ.Message, 0, len(req.Messages))
+
+	for _, m := range req.Messages {
+		switch m.Role {
+		case "system":
+			if systemPrompt.Len() > 0 {
+				systemPrompt.WriteByte('\n')
+			}
+			systemPrompt.WriteString(m.Content)
+		case "assistant":
+			msg := reZ.Message{Role: "assistant", Content: m.Content}
+			for _, tc := range m.ToolCalls {
+				msg.ToolCalls = append(msg.ToolCalls, reZ.ToolCall{
+					ID:   tc.ID,
+					Type: tc.Type,
+					Function: &reZ.Function{
+						Name:      tc.Function.Name,
+						Arguments: tc.Function.Arguments,
+					},
+				})
+			}
+			history = append(history, msg)
+		case "tool":
+			history = append(history, reZ.Message{Role: "tool", Content: m.Content})
+		default: // "user"
+			history = append(history, reZ.Message{Role: "user", Content: m.Content, FileContentList: []interface{}{}})
+		}
+	}
+
+	opts := make([]reZ.Option, 0, 5)
+	opts = append(opts, reZ.WithPrompt(history))
+	if systemPrompt.Len() > 0 {
+		opts = append(opts, reZ.WithSystemPrompt(systemPrompt.String()))
+	}
+	if model := s.resolveModel(req.Model); model != "" {
+		opts = append(opts, reZ.WithModel(model))
+	}
+	if req.Temperature != nil {
+		if *req.Temperature < 0.0 || *req.Temperature > 2.0 {
+			return nil, fmt.Errorf("temperature must be between 0.0 and 2.0, got: %.2f", *req.Temperature)
+		}
+		opts = append(opts, reZ.WithTemperature(*req.Temperature))
+	}
+	if req.MaxTokens != nil {
+		if *req.MaxTokens <= 0 {
+			return nil, fmt.Errorf("max_tokens must be greater than 0, got: %d", *req.MaxTokens)
+		}
+		opts = append(opts, reZ.WithMaxTokens(*req.MaxTokens))
+	}
+	if req.TopP != nil {
+		if *req.TopP < 0.0 || *req.TopP > 1.0 {
+			return nil, fmt.Errorf("top_p must be between 0.0 and 1.0, got: %.2f", *req.TopP)
+		}
+		opts = append(opts, reZ.WithTopP(*req.TopP))
+	}
+	opts = append(opts, s.translateTools(req)...)
+
+	return opts, nil
+}
+
+func (s *Server) resolveModel(requested string) string {
+	if model, ok := s.modelAliases[requested]; ok {
+		return model
+	}
+	return requested
+}
+
+// translateTools maps OpenAI tool/tool_choice fields onto WithFunction
+// options. A tool_choice naming a specific function restricts the call
+// to that one function, approximating OpenAI's forced tool calling;
+// "none" omits tools entirely.
+func (s *Server) translateTools(req *chatCompletionRequest) []reZ.Option {
+	if len(req.Tools) == 0 {
+		return nil
+	}
+
+	if choice, ok := req.ToolChoice.(string); ok && choice == "none" {
+		return nil
+	}
+
+	forced := ""
+	if choice, ok := req.ToolChoice.(map[string]interface{}); ok {
+		if fn, ok := choice["function"].(map[string]interface{}); ok {
+			if name, ok := fn["name"].(string); ok {
+				forced = name
+			}
+		}
+	}
+
+	opts := make([]reZ.Option, 0, len(req.Tools))
+	for _, t := range req.Tools {
+		if t.Type != "function" {
+			continue
+		}
+		if forced != "" && t.Function.Name != forced {
+			continue
+		}
+		opts = append(opts, reZ.WithFunction(t.Function.Name, t.Function.Description, t.Function.Parameters))
+	}
+	return opts
+}
+
+func (s *Server) aggregateCompletion(w http.ResponseWriter, events <-chan reZ.StreamEvent, id string, created int64, model string) {
+	var content strings.Builder
+	var reasoning strings.Builder
+	var toolCalls []chatToolCall
+	var streamErr error
+
+	for event := range events {
+		if event.Error != nil {
+			streamErr = event.Error
+			continue
+		}
+		content.WriteString(event.Text)
+		reasoning.WriteString(event.Think)
+		if event.ToolCall != nil {
+			toolCalls = append(toolCalls, fromReZToolCall(event.ToolCall))
+		}
+	}
+
+	if streamErr != nil {
+		writeError(w, http.StatusBadGateway, "upstream_error", streamErr.Error())
+		return
+	}
+
+	finishReason := "stop"
+	msg := chatMessage{Role: "assistant", Content: content.String()}
+	if len(toolCalls) > 0 {
+		msg.ToolCalls = toolCalls
+		finishReason = "tool_calls"
+	}
+
+	writeJSON(w, http.StatusOK, chatCompletionResponse{
+		ID:      id,
+		Object:  "chat.completion",
+		Created: created,
+		Model:   model,
+		Choices: []chatChoice{{Index: 0, Message: msg, FinishReason: finishReason}},
+	})
+}
+
+func (s *Server) streamCompletion(w http.ResponseWriter, events <-chan reZ.StreamEvent, id string, created int64, model string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "internal_error", "streaming unsupported by response writer")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	bw := bufio.NewWriter(w)
+	send := func(chunk chatCompletionChunk) {
+		data, _ := json.Marshal(chunk)
+		fmt.Fprintf(bw, "data: %s\n\n", data)
+		bw.Flush()
+		flusher.Flush()
+	}
+
+	send(chatCompletionChunk{
+		ID: id, Object: "chat.completion.chunk", Created: created, Model: model,
+		Choices: []chatChunkChoice{{Index: 0, Delta: chatDelta{Role: "assistant"}}},
+	})
+
+	sawToolCall := false
+	for event := range events {
+		if event.Error != nil {
+			send(chatCompletionChunk{
+				ID: id, Object: "chat.completion.chunk", Created: created, Model: model,
+				Choices: []chatChunkChoice{{Index: 0, Delta: chatDelta{Content: fmt.Sprintf("[error: %v]", event.Error)}}},
+			})
+			continue
+		}
+
+		var delta chatDelta
+		switch {
+		case event.Think != "":
+			delta.ReasoningContent = event.Think
+		case event.ToolCall != nil:
+			sawToolCall = true
+			delta.ToolCalls = []chatToolCall{fromReZToolCall(event.ToolCall)}
+		case event.Text != "":
+			delta.Content = event.Text
+		default:
+			continue
+		}
+
+		send(chatCompletionChunk{
+			ID: id, Object: "chat.completion.chunk", Created: created, Model: model,
+			Choices: []chatChunkChoice{{Index: 0, Delta: delta}},
+		})
+	}
+
+	finishReason := "stop"
+	if sawToolCall {
+		finishReason = "tool_calls"
+	}
+	send(chatCompletionChunk{
+		ID: id, Object: "chat.completion.chunk", Created: created, Model: model,
+		Choices: []chatChunkChoice{{Index: 0, Delta: chatDelta{}, FinishReason: &finishReason}},
+	})
+	fmt.Fprint(bw, "data: [DONE]\n\n")
+	bw.Flush()
+	flusher.Flush()
+}
+
+func fromReZToolCall(tc *reZ.ToolCall) chatToolCall {
+	call := chatToolCall{Index: tc.Index, ID: tc.ID, Type: "function"}
+	if tc.Function != nil {
+		call.Function = chatFunctionCall{Name: tc.Function.Name, Arguments: tc.Function.Arguments}
+	}
+	return call
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, typ, message string) {
+	writeJSON(w, status, errorResponse{Error: errorBody{Message: message, Type: typ}})
+}
+
+func randomID() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return hex.EncodeToString([]byte(time.Now().String()))[:24]
+	}
+	return hex.EncodeToString(b)
+}
@@ -1,19 +1,29 @@
 package reZ
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Request represents an API request to Zhipu AI.
 type Request struct {
-	Model        string     `json:"model"`
-	ModelID      int        `json:"modelId"`
-	Prompt       []Message  `json:"prompt"`
-	Stream       bool       `json:"stream"`
-	Thinking     *Thinking  `json:"thinking,omitempty"`
-	MaxTokens    int        `json:"max_tokens"`
-	Temperature  float64    `json:"temperature"`
-	TopP         float64    `json:"top_p"`
-	SystemPrompt string     `json:"system_prompt,omitempty"`
-	Tools        []Tool     `json:"tools,omitempty"`
+	Model        string    `json:"model"`
+	ModelID      int       `json:"modelId"`
+	Prompt       []Message `json:"prompt"`
+	Stream       bool      `json:"stream"`
+	Thinking     *Thinking `json:"thinking,omitempty"`
+	MaxTokens    int       `json:"max_tokens"`
+	Temperature  float64   `json:"temperature"`
+	TopP         float64   `json:"top_p"`
+	SystemPrompt string    `json:"system_prompt,omitempty"`
+	Tools        []Tool    `json:"tools,omitempty"`
+
+	// cacheTTL and noCache configure the response cache for this single
+	// request (see WithCacheTTL and WithNoCache). They are unexported so
+	// they never affect the wire payload sent to bigmodel.cn or the
+	// cache key derived from it.
+	cacheTTL *time.Duration
+	noCache  bool
 }
 
 // Message represents a message in the conversation.
@@ -71,6 +81,12 @@ type StreamEvent struct {
 	ToolCall *ToolCall
 	Raw      map[string]interface{}
 	Error    error
+
+	// Reconnected is true for events emitted after ChatWithHistory
+	// transparently resumed a mid-stream disconnect. Consumers that
+	// render output incrementally can use it to, for example, show a
+	// "reconnected" indicator instead of silently continuing.
+	Reconnected bool
 }
 
 // Option is a function that configures a Request.
@@ -123,6 +139,24 @@ func WithMaxTokens(tokens int) Option {
 	}
 }
 
+// WithTopP sets the nucleus sampling probability mass (0.0 to 1.0).
+// Lower values restrict sampling to a smaller set of likely tokens.
+// Default is 0.95.
+// Returns an error via panic if topP is out of range.
+//
+// Example:
+//
+//	client.Chat(ctx, "Write a story",
+//	    reZ.WithTopP(0.9))
+func WithTopP(topP float64) Option {
+	if topP < 0.0 || topP > 1.0 {
+		panic(fmt.Sprintf("top_p must be between 0.0 and 1.0, got: %.2f", topP))
+	}
+	return func(r *Request) {
+		r.TopP = topP
+	}
+}
+
 // WithThinking enables or disables the thinking mode.
 // When enabled, the AI shows its reasoning process via StreamEvent.Think.
 // Default is enabled.
@@ -141,6 +175,38 @@ func WithThinking(enabled bool) Option {
 	}
 }
 
+// WithModel overrides the model name sent in the request. Default is
+// "glm-4.6". The numeric model ID is left untouched, since the endpoint
+// this client talks to only ever serves one underlying model; this is
+// mainly useful for callers that need the outgoing payload to report a
+// caller-chosen name, such as an OpenAI-compatible proxy mapping model
+// aliases onto this client.
+//
+// Example:
+//
+//	client.Chat(ctx, "Hello", reZ.WithModel("glm-4.6-air"))
+func WithModel(name string) Option {
+	return func(r *Request) {
+		r.Model = name
+	}
+}
+
+// WithPrompt overrides the full prompt array sent to the model, bypassing
+// the single-message default Chat/ChatWithHistory build from the content
+// argument. Use this when you already have a complete, ordered
+// transcript (for example, one translated from another wire format) and
+// want a stateless call that never touches the Client's shared
+// conversation history.
+//
+// Example:
+//
+//	client.Chat(ctx, "", reZ.WithPrompt(transcript))
+func WithPrompt(messages []Message) Option {
+	return func(r *Request) {
+		r.Prompt = messages
+	}
+}
+
 // WithWebSearch enables web search functionality.
 // The AI can search the internet for real-time information.
 //
@@ -160,11 +226,11 @@ func WithWebSearch(opts ...WebSearchOption) Option {
 			SearchIntent:        false,
 			ContentSize:         "medium",
 		}
-		
+
 		for _, opt := range opts {
 			opt(ws)
 		}
-		
+
 		r.Tools = append(r.Tools, Tool{
 			Type:      "web_search",
 			WebSearch: ws,